@@ -4,10 +4,41 @@
 
 package apperr
 
+import "strings"
+
 type HTTPError struct {
 	Code    int
 	Message string
 	Data    any
+
+	// Type, Title, Instance, and Extensions are only used when the response is
+	// rendered as an RFC 7807 problem+json document (see httputils.SetErrorFormat).
+	// Type is a URI identifying the problem type; it defaults to "about:blank" if empty.
+	Type string
+	// Title is a short, human-readable summary of the problem type. If empty,
+	// Message is used as the title instead.
+	Title string
+	// Instance is a URI identifying this specific occurrence of the problem.
+	Instance string
+	// Extensions holds additional problem members to serialize as siblings of the
+	// standard RFC 7807 fields.
+	Extensions map[string]any
+
+	// Source identifies the part of the request this error is associated with,
+	// for rendering as a JSON:API-style source object (see httputils.SetErrorFormat).
+	Source Source
+}
+
+// Source identifies the part of the request an HTTPError is associated with,
+// following the JSON:API error object convention.
+type Source struct {
+	// Pointer is a JSON Pointer (RFC 6901) to the offending value in the request body,
+	// e.g. "/data/attributes/email".
+	Pointer string
+	// Parameter is the name of the offending URI query parameter.
+	Parameter string
+	// Header is the name of the offending request header.
+	Header string
 }
 
 // Error implements the error interface, returning the message string.
@@ -26,3 +57,17 @@ func New(code int, msg string) *HTTPError {
 func NewWithData(code int, msg string, data any) *HTTPError {
 	return &HTTPError{Code: code, Message: msg, Data: data}
 }
+
+// Errors aggregates multiple HTTPErrors into a single error value, so a handler can
+// report several problems at once (e.g. one per invalid field) instead of only the
+// first one encountered.
+type Errors []*HTTPError
+
+// Error implements the error interface by joining each member's message.
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Message
+	}
+	return strings.Join(msgs, "; ")
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package httputils
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/LullNil/go-http-utils/response"
+)
+
+// RequestIDHeader is the HTTP header used to propagate a request ID to and from clients.
+const RequestIDHeader = response.RequestIDHeader
+
+// WithLogger returns a copy of ctx carrying logger, retrievable with LoggerFrom.
+// Used by middleware.Logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return response.WithLogger(ctx, logger)
+}
+
+// LoggerFrom returns the *slog.Logger stashed in r's context by middleware.Logger,
+// or nil if none is present.
+func LoggerFrom(r *http.Request) *slog.Logger {
+	return response.LoggerFrom(r)
+}
+
+// WithRequestID returns a copy of ctx carrying id, retrievable with RequestIDFrom.
+// Used by middleware.RequestID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return response.WithRequestID(ctx, id)
+}
+
+// RequestIDFrom returns the request ID stashed in r's context by middleware.RequestID,
+// or "" if none is present.
+func RequestIDFrom(r *http.Request) string {
+	return response.RequestIDFrom(r)
+}
+
+// WithErrorFormat returns a copy of ctx carrying an ErrorFormat override for
+// requests using this context, overriding the process-wide format set by
+// SetErrorFormat for WriteHTTPError, ValidateRequest, DecodeRequest, and
+// response.Err.
+func WithErrorFormat(ctx context.Context, format ErrorFormat) context.Context {
+	return response.WithErrorFormat(ctx, format)
+}
+
+// currentFormat returns the ErrorFormat in effect for r: its WithErrorFormat
+// override if present, otherwise the process-wide default set by SetErrorFormat.
+func currentFormat(r *http.Request) ErrorFormat {
+	return response.FormatFrom(r)
+}
+
+// resolveLogger returns log if non-nil, otherwise the logger stashed in r's context,
+// falling back to slog.Default().
+func resolveLogger(log *slog.Logger, r *http.Request) *slog.Logger {
+	return response.ResolveLogger(log, r)
+}
+
+// setRequestIDHeader echoes the request ID stashed in r's context, if any, back
+// in the RequestIDHeader.
+func setRequestIDHeader(w http.ResponseWriter, r *http.Request) {
+	if id := RequestIDFrom(r); id != "" {
+		w.Header().Set(RequestIDHeader, id)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package httputils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/LullNil/go-http-utils/response"
+)
+
+// DefaultMaxBytes is the request body size limit used when DecodeOptions.MaxBytes
+// is zero.
+const DefaultMaxBytes int64 = 1 << 20 // 1 MiB
+
+// DecodeOptions configures DecodeRequest's parsing behavior.
+type DecodeOptions struct {
+	// MaxBytes caps the request body size; once the decoder has read more than
+	// this many bytes, decoding stops and the request is rejected with 413
+	// Payload Too Large. Zero means DefaultMaxBytes.
+	MaxBytes int64
+	// DisallowUnknownFields rejects payloads containing fields absent from T with
+	// 400 Bad Request.
+	DisallowUnknownFields bool
+	// RequireContentTypeJSON rejects requests whose Content-Type is not
+	// application/json with 415 Unsupported Media Type.
+	RequireContentTypeJSON bool
+}
+
+// DefaultDecodeOptions are the options DecodeRequest uses when called without an
+// explicit DecodeOptions.
+var DefaultDecodeOptions = DecodeOptions{MaxBytes: DefaultMaxBytes}
+
+// decodeErrorDetail pinpoints where in the payload a decode error occurred, for
+// inclusion in an error response's Data field.
+type decodeErrorDetail struct {
+	Field  string `json:"field,omitempty"`
+	Offset int64  `json:"offset,omitempty"`
+}
+
+// DecodeRequest parses and validates JSON body from the request into the given generic
+// struct. opts is optional; when omitted, DefaultDecodeOptions is used. log may be
+// nil, in which case the logger stashed in r's context by middleware.Logger is
+// used instead. Returns the struct and a boolean indicating success or failure.
+func DecodeRequest[T any](w http.ResponseWriter, r *http.Request, log *slog.Logger, op string, opts ...DecodeOptions) (T, bool) {
+	var req T
+
+	log = resolveLogger(log, r)
+	setRequestIDHeader(w, r)
+
+	opt := DefaultDecodeOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+		if opt.MaxBytes == 0 {
+			opt.MaxBytes = DefaultMaxBytes
+		}
+	}
+
+	if opt.RequireContentTypeJSON {
+		if ct := r.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+			err := fmt.Errorf("unsupported content type %q", ct)
+			log.Error("rejected request", slog.String("op", op), slog.String("err", err.Error()))
+			response.Err(log, w, r, op, err, "unsupported media type", http.StatusUnsupportedMediaType)
+			return req, false
+		}
+	}
+
+	body := http.MaxBytesReader(w, r.Body, opt.MaxBytes)
+	dec := json.NewDecoder(body)
+	if opt.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+
+	if err := dec.Decode(&req); err != nil {
+		log.Error("failed to decode request body", slog.String("op", op), slog.String("err", err.Error()))
+		status, msg, detail := decodeErrorResponse(err)
+		writeDecodeError(w, r, log, op, err, status, msg, detail)
+		return req, false
+	}
+
+	return req, true
+}
+
+// decodeErrorResponse maps a JSON decode error to an HTTP status, a human message,
+// and, where the error pinpoints a specific field or byte offset, a detail value
+// for the response's Data field.
+func decodeErrorResponse(err error) (status int, msg string, detail *decodeErrorDetail) {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return http.StatusRequestEntityTooLarge, "request body too large", nil
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return http.StatusBadRequest, "malformed JSON", &decodeErrorDetail{Offset: syntaxErr.Offset}
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return http.StatusBadRequest, "invalid value for field", &decodeErrorDetail{Field: typeErr.Field, Offset: typeErr.Offset}
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return http.StatusBadRequest, "unexpected end of JSON input", nil
+	}
+
+	if field, ok := unknownFieldFromError(err); ok {
+		return http.StatusBadRequest, "unknown field in request payload", &decodeErrorDetail{Field: field}
+	}
+
+	return http.StatusBadRequest, "invalid request payload", nil
+}
+
+// String renders d as a short human-readable phrase, for formats (problem+json,
+// JSON:API) that have no generic structured-data field to carry it verbatim.
+func (d decodeErrorDetail) String() string {
+	switch {
+	case d.Field != "" && d.Offset != 0:
+		return fmt.Sprintf("field %q, offset %d", d.Field, d.Offset)
+	case d.Field != "":
+		return fmt.Sprintf("field %q", d.Field)
+	case d.Offset != 0:
+		return fmt.Sprintf("offset %d", d.Offset)
+	default:
+		return ""
+	}
+}
+
+// writeDecodeError renders a decode failure through whichever ErrorFormat is in
+// effect for r (see currentFormat). When detail is nil, this is the same as
+// response.Err, which already dispatches across all three formats; when detail
+// is non-nil, the envelope format carries it verbatim in Data, while
+// problem+json and JSON:API — which have no generic structured-data field —
+// carry its String() rendering instead.
+func writeDecodeError(w http.ResponseWriter, r *http.Request, log *slog.Logger, op string, err error, status int, msg string, detail *decodeErrorDetail) {
+	if detail == nil {
+		response.Err(log, w, r, op, err, msg, status)
+		return
+	}
+
+	switch currentFormat(r) {
+	case FormatProblemJSON:
+		response.WriteProblem(w, response.Problem{Title: msg, Status: status, Detail: detail.String()})
+	case FormatJSONAPI:
+		je := response.JSONAPIError{Code: status, Title: msg, Detail: detail.String()}
+		if detail.Field != "" {
+			je.Source = &response.ErrorSource{Pointer: "/" + detail.Field}
+		}
+		response.WriteJSONAPIErrors(w, status, []response.JSONAPIError{je})
+	default:
+		Encode(w, r, status, response.DataWithError(msg, detail))
+	}
+}
+
+// unknownFieldPrefix is the (unexported, untyped) error message encoding/json
+// produces for a DisallowUnknownFields violation; the stdlib exposes no typed
+// error for this case.
+const unknownFieldPrefix = "json: unknown field "
+
+// unknownFieldFromError extracts the offending field name from a
+// DisallowUnknownFields decode error, if err is one.
+func unknownFieldFromError(err error) (field string, ok bool) {
+	msg := err.Error()
+	if !strings.HasPrefix(msg, unknownFieldPrefix) {
+		return "", false
+	}
+	return strings.Trim(strings.TrimPrefix(msg, unknownFieldPrefix), `"`), true
+}
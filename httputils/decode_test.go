@@ -0,0 +1,96 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package httputils
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type decodeTestPayload struct {
+	Name string `json:"name"`
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestDecodeRequest_Success(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"name":"alice"}`))
+	w := httptest.NewRecorder()
+
+	got, ok := DecodeRequest[decodeTestPayload](w, r, testLogger(), "test.decode")
+	if !ok {
+		t.Fatalf("DecodeRequest returned ok=false, want true; body=%s", w.Body.String())
+	}
+	if got.Name != "alice" {
+		t.Errorf("Name = %q, want %q", got.Name, "alice")
+	}
+}
+
+func TestDecodeRequest_MaxBytes(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"name":"alice"}`))
+	w := httptest.NewRecorder()
+
+	_, ok := DecodeRequest[decodeTestPayload](w, r, testLogger(), "test.decode", DecodeOptions{MaxBytes: 4})
+	if ok {
+		t.Fatal("DecodeRequest returned ok=true, want false for oversized body")
+	}
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestDecodeRequest_DisallowUnknownFields(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"name":"alice","extra":true}`))
+	w := httptest.NewRecorder()
+
+	_, ok := DecodeRequest[decodeTestPayload](w, r, testLogger(), "test.decode", DecodeOptions{
+		MaxBytes:              DefaultMaxBytes,
+		DisallowUnknownFields: true,
+	})
+	if ok {
+		t.Fatal("DecodeRequest returned ok=true, want false for unknown field")
+	}
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if !bytes.Contains(w.Body.Bytes(), []byte(`"field":"extra"`)) {
+		t.Errorf("body = %s, want it to contain the offending field name", w.Body.String())
+	}
+}
+
+func TestDecodeRequest_RequireContentTypeJSON(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"name":"alice"}`))
+	w := httptest.NewRecorder()
+
+	_, ok := DecodeRequest[decodeTestPayload](w, r, testLogger(), "test.decode", DecodeOptions{
+		MaxBytes:               DefaultMaxBytes,
+		RequireContentTypeJSON: true,
+	})
+	if ok {
+		t.Fatal("DecodeRequest returned ok=true, want false for missing Content-Type")
+	}
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnsupportedMediaType)
+	}
+}
+
+func TestDecodeRequest_MalformedJSON(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"name":`))
+	w := httptest.NewRecorder()
+
+	_, ok := DecodeRequest[decodeTestPayload](w, r, testLogger(), "test.decode")
+	if ok {
+		t.Fatal("DecodeRequest returned ok=true, want false for malformed JSON")
+	}
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
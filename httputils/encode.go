@@ -0,0 +1,132 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package httputils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/LullNil/go-http-utils/response"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Encoder serializes payload and writes it to w.
+type Encoder func(w io.Writer, payload any) error
+
+// encoders maps a Content-Type to the Encoder used to render it. It starts out
+// with JSON, MessagePack, protobuf, and CBOR encoders registered.
+var encoders = map[string]Encoder{
+	"application/json":       encodeJSON,
+	"application/msgpack":    encodeMsgpack,
+	"application/x-protobuf": encodeProtobuf,
+	"application/cbor":       encodeCBOR,
+}
+
+// RegisterEncoder registers (or replaces) the Encoder used for mime. This lets
+// services add formats of their own, or swap out a built-in implementation.
+func RegisterEncoder(mime string, enc Encoder) {
+	encoders[mime] = enc
+}
+
+// Encode writes payload to w using the Encoder registered for the best
+// Content-Type accepted by r's Accept header, defaulting to JSON when the
+// header is absent, empty, or "*/*". If the header is present and names only
+// Content-Types with no registered Encoder, Encode writes 406 Not Acceptable
+// instead. If payload is a response.Response, its RequestID field is populated
+// from r's context before encoding.
+//
+// payload is encoded into a buffer before anything is written to w, so an
+// encoding failure (e.g. a payload that doesn't implement proto.Message) never
+// leaves w with a committed status/Content-Type and an empty or truncated
+// body; instead it falls back to a logged 500 with a JSON error body.
+func Encode(w http.ResponseWriter, r *http.Request, status int, payload any) {
+	mime, enc, ok := negotiateEncoder(r)
+	if !ok {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if resp, ok := payload.(response.Response); ok {
+		resp.RequestID = RequestIDFrom(r)
+		payload = resp
+	}
+
+	var buf bytes.Buffer
+	if err := enc(&buf, payload); err != nil {
+		resolveLogger(nil, r).Error("failed to encode response",
+			slog.String("content_type", mime),
+			slog.String("err", err.Error()),
+		)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(response.Error("failed to encode response"))
+		return
+	}
+
+	w.Header().Set("Content-Type", mime)
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// negotiateEncoder picks the first Content-Type in r's Accept header for which
+// an Encoder is registered, defaulting to JSON when the header is absent, empty,
+// or accepts "*/*".
+func negotiateEncoder(r *http.Request) (mime string, enc Encoder, ok bool) {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return "application/json", encoders["application/json"], true
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mt := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		if mt == "*/*" {
+			return "application/json", encoders["application/json"], true
+		}
+		if enc, exists := encoders[mt]; exists {
+			return mt, enc, true
+		}
+	}
+	return "", nil, false
+}
+
+func encodeJSON(w io.Writer, payload any) error {
+	return json.NewEncoder(w).Encode(payload)
+}
+
+// encodeMsgpack reads the json struct tag (instead of msgpack's own, PascalCase
+// default) so a response negotiated as msgpack has the same field names and
+// omitempty behavior as the JSON and CBOR encoders for the same value.
+func encodeMsgpack(w io.Writer, payload any) error {
+	enc := msgpack.NewEncoder(w)
+	enc.SetCustomStructTag("json")
+	return enc.Encode(payload)
+}
+
+func encodeCBOR(w io.Writer, payload any) error {
+	return cbor.NewEncoder(w).Encode(payload)
+}
+
+// encodeProtobuf marshals payload as protobuf wire format. payload must
+// implement proto.Message; this excludes the package's default response.Response
+// envelope, which has no protobuf schema.
+func encodeProtobuf(w io.Writer, payload any) error {
+	msg, ok := payload.(proto.Message)
+	if !ok {
+		return fmt.Errorf("httputils: %T does not implement proto.Message", payload)
+	}
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package httputils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LullNil/go-http-utils/response"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestEncode_DefaultsToJSON(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	Encode(w, r, http.StatusOK, response.OK())
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want %q", ct, "application/json")
+	}
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestEncode_NotAcceptable(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "application/xml")
+	w := httptest.NewRecorder()
+
+	Encode(w, r, http.StatusOK, response.OK())
+
+	if w.Code != http.StatusNotAcceptable {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotAcceptable)
+	}
+}
+
+func TestEncode_EncoderFailureDoesNotLeakPartialResponse(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "application/x-protobuf")
+	w := httptest.NewRecorder()
+
+	Encode(w, r, http.StatusOK, response.OK())
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want %q", ct, "application/json")
+	}
+	if strings.TrimSpace(w.Body.String()) == "" {
+		t.Error("body is empty, want a JSON error response")
+	}
+}
+
+// TestEncode_MsgpackMatchesJSONFieldNames guards against vmihailenco/msgpack's
+// default of ignoring Go json struct tags, which would otherwise make a
+// msgpack-negotiated response describe a different document shape (PascalCase,
+// no omitempty) than the same value encoded as JSON or CBOR.
+func TestEncode_MsgpackMatchesJSONFieldNames(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "application/msgpack")
+	w := httptest.NewRecorder()
+
+	Encode(w, r, http.StatusOK, response.OK())
+
+	var got map[string]any
+	if err := msgpack.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("body did not decode as msgpack: %v", err)
+	}
+	if _, ok := got["status"]; !ok {
+		t.Errorf("decoded keys = %v, want a lowercase \"status\" key", got)
+	}
+	if _, ok := got["Status"]; ok {
+		t.Errorf("decoded keys = %v, want no PascalCase \"Status\" key", got)
+	}
+	if _, ok := got["data"]; ok {
+		t.Errorf("decoded keys = %v, want omitempty to drop the empty \"data\" field", got)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package httputils
+
+import (
+	"net/http"
+
+	"github.com/LullNil/go-http-utils/apperr"
+	"github.com/LullNil/go-http-utils/response"
+)
+
+// ErrorFormat selects the wire format used to render error responses.
+type ErrorFormat = response.ErrorFormat
+
+const (
+	// FormatEnvelope renders errors as the package's default {status, error, data} envelope.
+	// This is the default format.
+	FormatEnvelope = response.FormatEnvelope
+	// FormatProblemJSON renders errors as RFC 7807 (https://www.rfc-editor.org/rfc/rfc7807)
+	// application/problem+json documents.
+	FormatProblemJSON = response.FormatProblemJSON
+	// FormatJSONAPI renders errors as a JSON:API-style {"errors": [...]} document.
+	FormatJSONAPI = response.FormatJSONAPI
+)
+
+// SetErrorFormat sets the process-wide default wire format used by WriteHTTPError,
+// ValidateRequest, DecodeRequest, and response.Err for every subsequent error
+// response. It is not safe to call concurrently with in-flight requests; call it
+// once during startup. To select a format for a single request or handler instead
+// of globally, use WithErrorFormat on that request's context.
+func SetErrorFormat(f ErrorFormat) {
+	response.SetErrorFormat(f)
+}
+
+// problemFromHTTPError builds an RFC 7807 problem document from an apperr.HTTPError.
+// If httpErr.Title is empty, httpErr.Message is used as the problem's title.
+func problemFromHTTPError(httpErr *apperr.HTTPError) response.Problem {
+	p := response.Problem{
+		Type:       httpErr.Type,
+		Status:     httpErr.Code,
+		Instance:   httpErr.Instance,
+		Extensions: httpErr.Extensions,
+	}
+	if httpErr.Title != "" {
+		p.Title = httpErr.Title
+		p.Detail = httpErr.Message
+	} else {
+		p.Title = httpErr.Message
+	}
+	return p
+}
+
+// errorDetail is the envelope-format representation of a single apperr.HTTPError
+// when writeAppErrors renders more than one error into one response's Data field.
+type errorDetail struct {
+	Message string `json:"message"`
+	Pointer string `json:"pointer,omitempty"`
+}
+
+// problemFromErrors builds an RFC 7807 problem document from errs, for whichever
+// format is in effect. A single error keeps its own Type/Title/Instance/Extensions
+// (see problemFromHTTPError); multiple errors, which RFC 7807 has no native shape
+// for, are summarized as one problem listing each as an "errors" extension member.
+func problemFromErrors(errs apperr.Errors, status int) response.Problem {
+	if len(errs) == 1 {
+		return problemFromHTTPError(errs[0])
+	}
+	issues := make([]map[string]any, len(errs))
+	for i, e := range errs {
+		issue := map[string]any{"detail": e.Message}
+		if e.Source.Pointer != "" {
+			issue["pointer"] = e.Source.Pointer
+		}
+		issues[i] = issue
+	}
+	return response.Problem{
+		Title:      "multiple errors occurred",
+		Status:     status,
+		Extensions: map[string]any{"errors": issues},
+	}
+}
+
+// writeAppErrors renders errs in the request's current ErrorFormat (see
+// currentFormat). WriteHTTPError builds an apperr.Errors value for every error,
+// even a single one, and renders it here; validation and decode failures are
+// rendered by writeValidationErrors and writeDecodeError instead.
+func writeAppErrors(w http.ResponseWriter, r *http.Request, status int, errs apperr.Errors) {
+	switch currentFormat(r) {
+	case FormatJSONAPI:
+		writeJSONAPIErrors(w, errs)
+	case FormatProblemJSON:
+		response.WriteProblem(w, problemFromErrors(errs, status))
+	default:
+		if len(errs) == 1 {
+			if errs[0].Data != nil {
+				Encode(w, r, status, response.DataWithError(errs[0].Message, errs[0].Data))
+			} else {
+				Encode(w, r, status, response.Error(errs[0].Message))
+			}
+			return
+		}
+		details := make([]errorDetail, len(errs))
+		for i, e := range errs {
+			details[i] = errorDetail{Message: e.Message, Pointer: e.Source.Pointer}
+		}
+		Encode(w, r, status, response.DataWithError(errs.Error(), details))
+	}
+}
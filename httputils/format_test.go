@@ -0,0 +1,115 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package httputils
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LullNil/go-http-utils/apperr"
+)
+
+// withErrorFormat sets f as the process-wide default for the duration of the
+// test, restoring FormatEnvelope on cleanup so tests don't leak state into one
+// another (SetErrorFormat is a process-wide var, not safe to use concurrently).
+func withErrorFormat(t *testing.T, f ErrorFormat) {
+	t.Helper()
+	SetErrorFormat(f)
+	t.Cleanup(func() { SetErrorFormat(FormatEnvelope) })
+}
+
+func TestWriteHTTPError_ProblemJSON(t *testing.T) {
+	withErrorFormat(t, FormatProblemJSON)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	WriteHTTPError(w, r, testLogger(), "test.op", apperr.New(http.StatusConflict, "already exists"))
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("Content-Type = %q, want %q", ct, "application/problem+json")
+	}
+	if w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body did not decode as JSON: %v", err)
+	}
+	if body["title"] != "already exists" {
+		t.Errorf("title = %v, want %q", body["title"], "already exists")
+	}
+}
+
+func TestWriteHTTPError_JSONAPI_MappedError(t *testing.T) {
+	withErrorFormat(t, FormatJSONAPI)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	WriteHTTPError(w, r, testLogger(), "test.op", context.Canceled)
+
+	if w.Code != statusClientClosedRequest {
+		t.Errorf("status = %d, want %d", w.Code, statusClientClosedRequest)
+	}
+	if !strings.Contains(w.Body.String(), `"errors"`) {
+		t.Errorf("body = %s, want a JSON:API errors document", w.Body.String())
+	}
+}
+
+func TestWriteHTTPError_JSONAPI_GenericFallback(t *testing.T) {
+	withErrorFormat(t, FormatJSONAPI)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	WriteHTTPError(w, r, testLogger(), "test.op", &customError{"boom"})
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if !strings.Contains(w.Body.String(), `"errors"`) {
+		t.Errorf("body = %s, want a JSON:API errors document for the generic fallback", w.Body.String())
+	}
+}
+
+func TestValidateRequest_ProblemJSON(t *testing.T) {
+	withErrorFormat(t, FormatProblemJSON)
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	w := httptest.NewRecorder()
+
+	if ValidateRequest(w, r, testLogger(), "test.validate", validateTestPayload{}) {
+		t.Fatal("ValidateRequest returned ok=true, want false")
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("Content-Type = %q, want %q", ct, "application/problem+json")
+	}
+}
+
+func TestWithErrorFormat_PerRequestOverride(t *testing.T) {
+	withErrorFormat(t, FormatProblemJSON)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r = r.WithContext(WithErrorFormat(r.Context(), FormatJSONAPI))
+	w := httptest.NewRecorder()
+
+	WriteHTTPError(w, r, testLogger(), "test.op", apperr.New(http.StatusConflict, "already exists"))
+
+	if ct := w.Header().Get("Content-Type"); ct == "application/problem+json" {
+		t.Error("request's WithErrorFormat override was ignored in favor of the process-wide default")
+	}
+	if !strings.Contains(w.Body.String(), `"errors"`) {
+		t.Errorf("body = %s, want a JSON:API errors document per the per-request override", w.Body.String())
+	}
+}
+
+type customError struct{ msg string }
+
+func (e *customError) Error() string { return e.msg }
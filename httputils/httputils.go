@@ -5,7 +5,6 @@
 package httputils
 
 import (
-	"encoding/json"
 	"errors"
 	"net/http"
 
@@ -18,64 +17,84 @@ import (
 
 var validate = validator.New()
 
-// DecodeRequest parses and validates JSON body from the request into the given generic struct.
-// Returns the struct and a boolean indicating success or failure.
-func DecodeRequest[T any](w http.ResponseWriter, r *http.Request, log *slog.Logger, op string) (T, bool) {
-	var req T
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Error("failed to decode request body", slog.String("op", op), slog.String("err", err.Error()))
-		response.Err(w, r, log, op, err, "invalid request payload", http.StatusBadRequest)
-		return req, false
-	}
-
-	return req, true
-}
-
 // ValidateRequest checks the struct against validation tags.
-// Returns true if valid, otherwise sends error response and returns false.
+// log may be nil, in which case the logger stashed in r's context by
+// middleware.Logger is used instead. Returns true if valid, otherwise sends a
+// structured error response and returns false.
 func ValidateRequest[T any](w http.ResponseWriter, r *http.Request, log *slog.Logger, op string, req T) bool {
+	log = resolveLogger(log, r)
+	setRequestIDHeader(w, r)
+
 	if err := validate.Struct(req); err != nil {
 		log.Error("validation failed",
 			slog.String("op", op),
 			slog.String("err", err.Error()),
-			slog.Any("validation_errors", err.Error()),
 		)
-		response.Err(w, r, log, op, err, "invalid input data", http.StatusBadRequest)
+
+		var verrs validator.ValidationErrors
+		if errors.As(err, &verrs) {
+			writeValidationErrors(w, r, verrs)
+			return false
+		}
+
+		response.Err(log, w, r, op, err, "invalid input data", http.StatusBadRequest)
 		return false
 	}
 	return true
 }
 
-// SendOK sends a standard JSON success response with HTTP 200.
+// SendOK sends a standard success response with HTTP 200, encoded per r's Accept
+// header (see Encode). log may be nil, in which case the logger stashed in r's
+// context by middleware.Logger is used instead.
 func SendOK(w http.ResponseWriter, r *http.Request, log *slog.Logger, op string) {
-	writeJSON(w, http.StatusOK, response.OK())
+	log = resolveLogger(log, r)
+	setRequestIDHeader(w, r)
+	Encode(w, r, http.StatusOK, response.OK())
 	log.Info("operation successful", slog.String("op", op))
 }
 
-// SendDataOK sends a JSON response with the given data and HTTP 200 status.
-// Automatically logs the operation as successful.
+// SendDataOK sends a response with the given data and HTTP 200 status, encoded
+// per r's Accept header (see Encode). log may be nil, in which case the logger
+// stashed in r's context by middleware.Logger is used instead. Automatically
+// logs the operation as successful.
 func SendDataOK(w http.ResponseWriter, r *http.Request, log *slog.Logger, op string, data any) {
-	writeJSON(w, http.StatusOK, response.DataOK(data))
+	log = resolveLogger(log, r)
+	setRequestIDHeader(w, r)
+	Encode(w, r, http.StatusOK, response.DataOK(data))
 	log.Info("operation successful", slog.String("op", op))
 }
 
 // WriteHTTPError writes an HTTP error response to w based on the given error.
-// If the error is an apperr.HTTPError, it will be used directly.
-// Otherwise, it will be logged and an internal server error will be written.
-func WriteHTTPError(w http.ResponseWriter, log *slog.Logger, op string, err error) {
+// log may be nil, in which case the logger stashed in r's context by
+// middleware.Logger is used instead. If the error is an apperr.Errors, all of
+// its members are rendered in one response, in the ErrorFormat in effect for r.
+// If it is a single apperr.HTTPError, it will be used directly. Otherwise, errorMappers is
+// consulted (see RegisterErrorMapper); if nothing matches, an internal server
+// error is written.
+func WriteHTTPError(w http.ResponseWriter, r *http.Request, log *slog.Logger, op string, err error) {
+	log = resolveLogger(log, r)
+	setRequestIDHeader(w, r)
+
+	var errs apperr.Errors
+	if errors.As(err, &errs) {
+		log.Error("handled errors", slog.String("op", op), slog.String("err", errs.Error()))
+		writeAppErrors(w, r, overallStatus(errs), errs)
+		return
+	}
+
 	var httpErr *apperr.HTTPError
 	if errors.As(err, &httpErr) {
 		log.Error("handled error",
 			slog.String("op", op),
 			slog.String("err", httpErr.Error()),
 		)
+		writeAppErrors(w, r, httpErr.Code, apperr.Errors{httpErr})
+		return
+	}
 
-		if httpErr.Data != nil {
-			writeJSON(w, httpErr.Code, response.DataWithError(httpErr.Message, httpErr.Data))
-		} else {
-			writeJSON(w, httpErr.Code, response.Error(httpErr.Message))
-		}
+	if mappedErr, ok := errorMappers.Map(err); ok {
+		log.Error("mapped error", slog.String("op", op), slog.String("err", mappedErr.Error()))
+		writeAppErrors(w, r, mappedErr.Code, apperr.Errors{mappedErr})
 		return
 	}
 
@@ -84,11 +103,5 @@ func WriteHTTPError(w http.ResponseWriter, log *slog.Logger, op string, err erro
 		slog.String("err", err.Error()),
 	)
 
-	writeJSON(w, http.StatusInternalServerError, response.Error("internal server error"))
-}
-
-func writeJSON(w http.ResponseWriter, status int, data any) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(data)
+	writeAppErrors(w, r, http.StatusInternalServerError, apperr.Errors{apperr.New(http.StatusInternalServerError, "internal server error")})
 }
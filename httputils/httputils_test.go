@@ -0,0 +1,59 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package httputils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type validateTestPayload struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func TestValidateRequest_Success(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	w := httptest.NewRecorder()
+
+	ok := ValidateRequest(w, r, testLogger(), "test.validate", validateTestPayload{Name: "alice"})
+	if !ok {
+		t.Fatalf("ValidateRequest returned ok=false, want true; body=%s", w.Body.String())
+	}
+}
+
+func TestValidateRequest_FieldErrors(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	w := httptest.NewRecorder()
+
+	ok := ValidateRequest(w, r, testLogger(), "test.validate", validateTestPayload{})
+	if ok {
+		t.Fatal("ValidateRequest returned ok=true, want false for missing required field")
+	}
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+// TestValidateRequest_NonValidationError exercises the path where
+// validate.Struct returns something other than validator.ValidationErrors (here,
+// *validator.InvalidValidationError for a non-struct argument), which falls
+// through to response.Err. This guards against the argument-order mismatch
+// between that call and Err's signature regressing unnoticed.
+func TestValidateRequest_NonValidationError(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	w := httptest.NewRecorder()
+
+	ok := ValidateRequest[map[string]string](w, r, testLogger(), "test.validate", nil)
+	if ok {
+		t.Fatal("ValidateRequest returned ok=true, want false for a non-struct argument")
+	}
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if w.Body.Len() == 0 {
+		t.Error("body is empty, want an error response")
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package httputils
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/LullNil/go-http-utils/apperr"
+	"github.com/LullNil/go-http-utils/response"
+	"github.com/go-playground/validator/v10"
+)
+
+// writeJSONAPIErrors renders errs as a JSON:API-style {"errors": [...]} document.
+// The overall HTTP status is the highest status among errs, except when every
+// member is a plain validation error (http.StatusBadRequest), in which case
+// http.StatusUnprocessableEntity is used instead.
+func writeJSONAPIErrors(w http.ResponseWriter, errs apperr.Errors) {
+	docErrs := make([]response.JSONAPIError, len(errs))
+	for i, e := range errs {
+		docErrs[i] = jsonAPIErrorFromHTTPError(e)
+	}
+	response.WriteJSONAPIErrors(w, overallStatus(errs), docErrs)
+}
+
+func jsonAPIErrorFromHTTPError(e *apperr.HTTPError) response.JSONAPIError {
+	je := response.JSONAPIError{Code: e.Code}
+	if e.Title != "" {
+		je.Title = e.Title
+		je.Detail = e.Message
+	} else {
+		je.Title = e.Message
+	}
+	if e.Source != (apperr.Source{}) {
+		je.Source = &response.ErrorSource{
+			Pointer:   e.Source.Pointer,
+			Parameter: e.Source.Parameter,
+			Header:    e.Source.Header,
+		}
+	}
+	return je
+}
+
+// writeValidationErrors renders verrs through whichever ErrorFormat is in effect
+// for r (see currentFormat): a JSON:API {"errors": [...]} document, an RFC 7807
+// problem+json document listing each failed field as an extension member, or
+// the package's default envelope with a []FieldError Data payload.
+func writeValidationErrors(w http.ResponseWriter, r *http.Request, verrs validator.ValidationErrors) {
+	fields := fieldErrors(verrs)
+	switch currentFormat(r) {
+	case FormatJSONAPI:
+		writeJSONAPIErrors(w, errorsFromValidation(verrs))
+	case FormatProblemJSON:
+		response.WriteProblem(w, response.Problem{
+			Title:      "invalid input data",
+			Status:     http.StatusBadRequest,
+			Extensions: map[string]any{"errors": fields},
+		})
+	default:
+		Encode(w, r, http.StatusBadRequest, response.DataWithError("invalid input data", fields))
+	}
+}
+
+// errorsFromValidation converts a validator.ValidationErrors into an apperr.Errors,
+// one HTTPError per failed field, each carrying a Source.Pointer that locates the
+// field in the request body.
+func errorsFromValidation(verrs validator.ValidationErrors) apperr.Errors {
+	out := make(apperr.Errors, len(verrs))
+	for i, fe := range verrs {
+		out[i] = &apperr.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: fieldErrorMessage(fe),
+			Source:  apperr.Source{Pointer: jsonPointer(fe)},
+		}
+	}
+	return out
+}
+
+// jsonPointer builds a JSON Pointer (RFC 6901) to fe's field from its
+// namespace, e.g. "User.Address.City" -> "/Address/City". fe.Field() alone
+// would only give the leaf name, losing the path for nested structs.
+func jsonPointer(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		ns = ns[i+1:]
+	}
+	return "/" + strings.ReplaceAll(ns, ".", "/")
+}
+
+// overallStatus picks the HTTP status for a JSON:API error document: the highest
+// status among errs, or http.StatusUnprocessableEntity when every member is a
+// plain validation error (http.StatusBadRequest).
+func overallStatus(errs apperr.Errors) int {
+	allValidation := true
+	status := 0
+	for _, e := range errs {
+		if e.Code > status {
+			status = e.Code
+		}
+		if e.Code != http.StatusBadRequest {
+			allValidation = false
+		}
+	}
+	if allValidation {
+		return http.StatusUnprocessableEntity
+	}
+	return status
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package httputils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type addressPayload struct {
+	City string `json:"city" validate:"required"`
+}
+
+type nestedValidatePayload struct {
+	Address addressPayload `json:"address" validate:"required"`
+}
+
+func TestValidateRequest_JSONAPI_NestedFieldPointer(t *testing.T) {
+	withErrorFormat(t, FormatJSONAPI)
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	w := httptest.NewRecorder()
+
+	if ValidateRequest(w, r, testLogger(), "test.nested", nestedValidatePayload{}) {
+		t.Fatal("ValidateRequest returned ok=true, want false")
+	}
+
+	var doc struct {
+		Errors []struct {
+			Source struct {
+				Pointer string `json:"pointer"`
+			} `json:"source"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("body did not decode as a JSON:API document: %v", err)
+	}
+	if len(doc.Errors) != 1 {
+		t.Fatalf("len(errors) = %d, want 1; body=%s", len(doc.Errors), w.Body.String())
+	}
+	if got, want := doc.Errors[0].Source.Pointer, "/address/city"; got != want {
+		t.Errorf("pointer = %q, want %q", got, want)
+	}
+}
+
+func TestValidateRequest_JSONAPI_MultipleErrors(t *testing.T) {
+	withErrorFormat(t, FormatJSONAPI)
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	w := httptest.NewRecorder()
+
+	if ValidateRequest(w, r, testLogger(), "test.multi", struct {
+		A string `json:"a" validate:"required"`
+		B string `json:"b" validate:"required"`
+	}{}) {
+		t.Fatal("ValidateRequest returned ok=true, want false")
+	}
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d for an all-validation error document", w.Code, http.StatusUnprocessableEntity)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package httputils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/LullNil/go-http-utils/apperr"
+)
+
+// statusClientClosedRequest is the non-standard status nginx popularized for a
+// request whose client disconnected before the server finished handling it.
+const statusClientClosedRequest = 499
+
+// ErrorMapper translates a domain error into an apperr.HTTPError. It returns
+// false when it does not recognize err, so WriteHTTPError can try the next mapper
+// in the chain.
+type ErrorMapper func(err error) (*apperr.HTTPError, bool)
+
+// MapperChain runs a sequence of ErrorMappers in order, returning the first match.
+type MapperChain []ErrorMapper
+
+// Map runs c's mappers in order and returns the first one that recognizes err.
+func (c MapperChain) Map(err error) (*apperr.HTTPError, bool) {
+	for _, m := range c {
+		if httpErr, ok := m(err); ok {
+			return httpErr, true
+		}
+	}
+	return nil, false
+}
+
+// errorMappers is consulted by WriteHTTPError, in registration order, for any
+// error that isn't already an apperr.Errors or *apperr.HTTPError. It starts out
+// with mappers for common standard-library sentinels.
+var errorMappers = MapperChain{
+	mapContextCanceled,
+	mapContextDeadlineExceeded,
+	mapJSONSyntaxError,
+}
+
+// RegisterErrorMapper appends m to the chain of error mappers WriteHTTPError
+// consults before falling back to a generic 500. This lets callers translate
+// sentinel errors from their own domain packages (sql.ErrNoRows, os.ErrNotExist,
+// gRPC status errors, a custom ErrConflict, ...) into typed HTTPErrors without
+// every handler wrapping them by hand.
+func RegisterErrorMapper(m ErrorMapper) {
+	errorMappers = append(errorMappers, m)
+}
+
+func mapContextCanceled(err error) (*apperr.HTTPError, bool) {
+	if errors.Is(err, context.Canceled) {
+		return apperr.New(statusClientClosedRequest, "request canceled by client"), true
+	}
+	return nil, false
+}
+
+func mapContextDeadlineExceeded(err error) (*apperr.HTTPError, bool) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return apperr.New(http.StatusGatewayTimeout, "request deadline exceeded"), true
+	}
+	return nil, false
+}
+
+func mapJSONSyntaxError(err error) (*apperr.HTTPError, bool) {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return apperr.New(http.StatusBadRequest, "malformed JSON"), true
+	}
+	return nil, false
+}
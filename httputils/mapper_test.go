@@ -0,0 +1,65 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package httputils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LullNil/go-http-utils/apperr"
+)
+
+var errSentinel = errors.New("sentinel: out of stock")
+
+func TestWriteHTTPError_BuiltinMappers(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"context canceled", context.Canceled, statusClientClosedRequest},
+		{"context deadline exceeded", context.DeadlineExceeded, http.StatusGatewayTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			w := httptest.NewRecorder()
+			WriteHTTPError(w, r, testLogger(), "test.op", tt.err)
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestRegisterErrorMapper(t *testing.T) {
+	RegisterErrorMapper(func(err error) (*apperr.HTTPError, bool) {
+		if errors.Is(err, errSentinel) {
+			return apperr.New(http.StatusConflict, "item out of stock"), true
+		}
+		return nil, false
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	WriteHTTPError(w, r, testLogger(), "test.op", errSentinel)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
+
+func TestWriteHTTPError_UnmappedFallsBackTo500(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	WriteHTTPError(w, r, testLogger(), "test.op", errors.New("totally unrecognized"))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+}
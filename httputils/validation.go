@@ -0,0 +1,70 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package httputils
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes a single failed validation rule for one field of a request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+func init() {
+	validate.RegisterTagNameFunc(jsonTagName)
+}
+
+// jsonTagName makes validator report the `json` struct tag instead of the Go field
+// name, so FieldError.Field matches what the client actually sent.
+func jsonTagName(fld reflect.StructField) string {
+	name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	if name == "-" || name == "" {
+		return fld.Name
+	}
+	return name
+}
+
+// RegisterValidation registers a custom validation function on the shared validator,
+// allowing consumers to add domain-specific rules (e.g. "username", "slug") without
+// reaching into an internal singleton.
+func RegisterValidation(tag string, fn validator.Func) error {
+	return validate.RegisterValidation(tag, fn)
+}
+
+// RegisterAlias registers a tag alias that expands to a combination of existing tags.
+func RegisterAlias(alias, tags string) {
+	validate.RegisterAlias(alias, tags)
+}
+
+// fieldErrors converts a validator.ValidationErrors into the package's FieldError
+// representation, suitable for embedding in a response.Response's Data field.
+func fieldErrors(err validator.ValidationErrors) []FieldError {
+	out := make([]FieldError, 0, len(err))
+	for _, fe := range err {
+		out = append(out, FieldError{
+			Field:   fe.Field(),
+			Tag:     fe.Tag(),
+			Param:   fe.Param(),
+			Message: fieldErrorMessage(fe),
+		})
+	}
+	return out
+}
+
+// fieldErrorMessage builds a human-readable message for a single failed rule.
+func fieldErrorMessage(fe validator.FieldError) string {
+	if fe.Param() == "" {
+		return fmt.Sprintf("field %q failed validation on %q", fe.Field(), fe.Tag())
+	}
+	return fmt.Sprintf("field %q failed validation on %q (%s)", fe.Field(), fe.Tag(), fe.Param())
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package httputils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type aliasTestPayload struct {
+	Username string `json:"username" validate:"username_fmt"`
+}
+
+func TestRegisterValidation_CustomRule(t *testing.T) {
+	if err := RegisterValidation("nonzero", func(fl validator.FieldLevel) bool {
+		return fl.Field().Int() != 0
+	}); err != nil {
+		t.Fatalf("RegisterValidation failed: %v", err)
+	}
+
+	type payload struct {
+		Count int `json:"count" validate:"nonzero"`
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	w := httptest.NewRecorder()
+	if ValidateRequest(w, r, testLogger(), "test.custom", payload{Count: 0}) {
+		t.Fatal("ValidateRequest returned ok=true, want false for Count=0")
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/", nil)
+	w = httptest.NewRecorder()
+	if !ValidateRequest(w, r, testLogger(), "test.custom", payload{Count: 5}) {
+		t.Fatalf("ValidateRequest returned ok=false, want true for Count=5; body=%s", w.Body.String())
+	}
+}
+
+func TestRegisterAlias(t *testing.T) {
+	RegisterAlias("username_fmt", "required,alphanum,min=3")
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	w := httptest.NewRecorder()
+	if ValidateRequest(w, r, testLogger(), "test.alias", aliasTestPayload{Username: "ab"}) {
+		t.Fatal("ValidateRequest returned ok=true, want false for a username shorter than the alias requires")
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/", nil)
+	w = httptest.NewRecorder()
+	if !ValidateRequest(w, r, testLogger(), "test.alias", aliasTestPayload{Username: "abcdef"}) {
+		t.Fatalf("ValidateRequest returned ok=false, want true for a valid username; body=%s", w.Body.String())
+	}
+}
+
+func TestFieldErrorMessage_WithAndWithoutParam(t *testing.T) {
+	type payload struct {
+		Name string `json:"name" validate:"required"`
+		Age  int    `json:"age" validate:"min=18"`
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	w := httptest.NewRecorder()
+	ok := ValidateRequest(w, r, testLogger(), "test.fields", payload{Age: 10})
+	if ok {
+		t.Fatal("ValidateRequest returned ok=true, want false")
+	}
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	var body struct {
+		Data []FieldError `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body did not decode as JSON: %v", err)
+	}
+	if len(body.Data) != 2 {
+		t.Fatalf("len(data) = %d, want 2; body=%s", len(body.Data), w.Body.String())
+	}
+	if got, want := body.Data[0].Message, `field "name" failed validation on "required"`; got != want {
+		t.Errorf("message without param = %q, want %q", got, want)
+	}
+	if got, want := body.Data[1].Message, `field "age" failed validation on "min" (18)`; got != want {
+		t.Errorf("message with param = %q, want %q", got, want)
+	}
+}
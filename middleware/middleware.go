@@ -0,0 +1,56 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+// Package middleware provides request-ID and request-scoped logging middleware
+// that plugs into the response helpers in the httputils package.
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+
+	"github.com/LullNil/go-http-utils/httputils"
+)
+
+// RequestID reads httputils.RequestIDHeader from the incoming request, generating
+// a new ID when it is absent, stashes it in the request context for
+// httputils.RequestIDFrom to retrieve, and echoes it back in the response header.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(httputils.RequestIDHeader)
+		if id == "" {
+			id = newRequestID()
+		}
+		w.Header().Set(httputils.RequestIDHeader, id)
+		r = r.WithContext(httputils.WithRequestID(r.Context(), id))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Logger returns middleware that stashes a per-request *slog.Logger, derived from
+// base with request_id, method, path, and remote_addr attributes, into the
+// request context for httputils.LoggerFrom to retrieve.
+func Logger(base *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger := base.With(
+				slog.String("request_id", httputils.RequestIDFrom(r)),
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("remote_addr", r.RemoteAddr),
+			)
+			r = r.WithContext(httputils.WithLogger(r.Context(), logger))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// newRequestID generates a random 16-byte request ID, hex-encoded.
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
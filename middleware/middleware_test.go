@@ -0,0 +1,84 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LullNil/go-http-utils/httputils"
+)
+
+func TestRequestID_GeneratesWhenAbsent(t *testing.T) {
+	var gotID string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID = httputils.RequestIDFrom(r)
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	RequestID(next).ServeHTTP(w, r)
+
+	if gotID == "" {
+		t.Fatal("RequestIDFrom returned \"\", want a generated ID")
+	}
+	if got := w.Header().Get(httputils.RequestIDHeader); got != gotID {
+		t.Errorf("response header = %q, want %q", got, gotID)
+	}
+}
+
+func TestRequestID_EchoesIncoming(t *testing.T) {
+	var gotID string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID = httputils.RequestIDFrom(r)
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(httputils.RequestIDHeader, "fixed-id")
+	w := httptest.NewRecorder()
+	RequestID(next).ServeHTTP(w, r)
+
+	if gotID != "fixed-id" {
+		t.Errorf("RequestIDFrom = %q, want %q", gotID, "fixed-id")
+	}
+	if got := w.Header().Get(httputils.RequestIDHeader); got != "fixed-id" {
+		t.Errorf("response header = %q, want %q", got, "fixed-id")
+	}
+}
+
+func TestLogger_StashesLoggerInContext(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.New(slog.NewTextHandler(&buf, nil))
+
+	var gotLogger *slog.Logger
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotLogger = httputils.LoggerFrom(r)
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	Logger(base)(next).ServeHTTP(w, r)
+
+	if gotLogger == nil {
+		t.Fatal("LoggerFrom returned nil, want the per-request logger")
+	}
+	gotLogger.Info("test message")
+	if !bytes.Contains(buf.Bytes(), []byte("test message")) {
+		t.Errorf("log output = %s, want it to contain the logged message", buf.String())
+	}
+}
+
+func TestLogger_NoopDiscardBase(t *testing.T) {
+	base := slog.New(slog.NewTextHandler(io.Discard, nil))
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	Logger(base)(next).ServeHTTP(w, r)
+}
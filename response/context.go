@@ -0,0 +1,72 @@
+// Copyright (c) 2025 LullNil. All rights reserved.
+// Use of this source code is governed by a MIT license that can be
+// found in the LICENSE file.
+
+package response
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+// RequestIDHeader is the HTTP header used to propagate a request ID to and from clients.
+const RequestIDHeader = "X-Request-ID"
+
+type ctxKey int
+
+const (
+	loggerCtxKey ctxKey = iota
+	requestIDCtxKey
+	errorFormatCtxKey
+)
+
+// WithLogger returns a copy of ctx carrying logger, retrievable with LoggerFrom.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey, logger)
+}
+
+// LoggerFrom returns the *slog.Logger stashed in r's context, or nil if none is present.
+func LoggerFrom(r *http.Request) *slog.Logger {
+	logger, _ := r.Context().Value(loggerCtxKey).(*slog.Logger)
+	return logger
+}
+
+// WithRequestID returns a copy of ctx carrying id, retrievable with RequestIDFrom.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey, id)
+}
+
+// RequestIDFrom returns the request ID stashed in r's context, or "" if none is present.
+func RequestIDFrom(r *http.Request) string {
+	id, _ := r.Context().Value(requestIDCtxKey).(string)
+	return id
+}
+
+// WithErrorFormat returns a copy of ctx carrying an ErrorFormat override for
+// requests using this context, retrievable with FormatFrom. Requests without an
+// override use the process-wide default set by SetErrorFormat.
+func WithErrorFormat(ctx context.Context, format ErrorFormat) context.Context {
+	return context.WithValue(ctx, errorFormatCtxKey, format)
+}
+
+// FormatFrom returns the ErrorFormat stashed in r's context by WithErrorFormat,
+// or CurrentErrorFormat() if none is present.
+func FormatFrom(r *http.Request) ErrorFormat {
+	if f, ok := r.Context().Value(errorFormatCtxKey).(ErrorFormat); ok {
+		return f
+	}
+	return CurrentErrorFormat()
+}
+
+// ResolveLogger returns log if non-nil, otherwise the logger stashed in r's context,
+// falling back to slog.Default().
+func ResolveLogger(log *slog.Logger, r *http.Request) *slog.Logger {
+	if log != nil {
+		return log
+	}
+	if logger := LoggerFrom(r); logger != nil {
+		return logger
+	}
+	return slog.Default()
+}
@@ -11,9 +11,10 @@ import (
 )
 
 type Response struct {
-	Status string `json:"status"`
-	Data   any    `json:"data,omitempty"`
-	Error  string `json:"error,omitempty"`
+	Status    string `json:"status"`
+	Data      any    `json:"data,omitempty"`
+	Error     string `json:"error,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
 }
 
 const (
@@ -21,6 +22,99 @@ const (
 	StatusError = "Error"
 )
 
+// ErrorFormat selects the wire format used to render error responses.
+type ErrorFormat int
+
+const (
+	// FormatEnvelope renders errors as the package's default {status, error, data} envelope.
+	FormatEnvelope ErrorFormat = iota
+	// FormatProblemJSON renders errors as RFC 7807 application/problem+json documents.
+	FormatProblemJSON
+	// FormatJSONAPI renders errors as a JSON:API-style {"errors": [...]} document.
+	FormatJSONAPI
+)
+
+var errorFormat = FormatEnvelope
+
+// SetErrorFormat sets the wire format used by Err (and, via httputils, WriteHTTPError)
+// for every subsequent error response. It is not safe to call concurrently with
+// in-flight requests.
+func SetErrorFormat(f ErrorFormat) {
+	errorFormat = f
+}
+
+// CurrentErrorFormat returns the format previously set with SetErrorFormat.
+func CurrentErrorFormat() ErrorFormat {
+	return errorFormat
+}
+
+// Problem is an RFC 7807 (https://www.rfc-editor.org/rfc/rfc7807) problem detail document.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]any
+}
+
+// MarshalJSON renders the problem's fixed members alongside its extension members,
+// as required by RFC 7807 ("additional members... are serialized as siblings").
+func (p Problem) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+	if p.Type != "" {
+		m["type"] = p.Type
+	}
+	m["title"] = p.Title
+	m["status"] = p.Status
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+	return json.Marshal(m)
+}
+
+// WriteProblem writes p to w as an application/problem+json document with p.Status
+// as the HTTP status code.
+func WriteProblem(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// ErrorSource identifies the part of the request a JSON:API error is associated
+// with, following the JSON:API error object convention.
+type ErrorSource struct {
+	Pointer   string `json:"pointer,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+	Header    string `json:"header,omitempty"`
+}
+
+// JSONAPIError is a single error object in a JSON:API-style error document.
+type JSONAPIError struct {
+	Code   int          `json:"code,omitempty"`
+	Title  string       `json:"title"`
+	Detail string       `json:"detail,omitempty"`
+	Source *ErrorSource `json:"source,omitempty"`
+}
+
+type jsonAPIDocument struct {
+	Errors []JSONAPIError `json:"errors"`
+}
+
+// WriteJSONAPIErrors writes errs to w as a JSON:API-style {"errors": [...]} document
+// with the given HTTP status code.
+func WriteJSONAPIErrors(w http.ResponseWriter, status int, errs []JSONAPIError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(jsonAPIDocument{Errors: errs})
+}
+
 func OK() Response {
 	return Response{Status: StatusOK}
 }
@@ -40,13 +134,41 @@ func DataWithError(msg string, data any) Response {
 	}
 }
 
+// Error returns an error Response carrying msg and no data payload.
+func Error(msg string) Response {
+	return errorResp(msg)
+}
+
 func errorResp(msg string) Response {
 	return Response{Status: StatusError, Error: msg}
 }
 
+// Err logs and writes an error response. If log is nil, the logger stashed in r's
+// context by middleware.Logger is used instead, falling back to slog.Default().
+// The request ID stashed in r's context, if any, is echoed back in the
+// RequestIDHeader and, when the default envelope format is active, in the
+// response body's request_id field.
 func Err(log *slog.Logger, w http.ResponseWriter, r *http.Request, op string, err error, msg string, httpStatus int) {
+	log = ResolveLogger(log, r)
 	log.Error(msg, slog.String("op", op), slog.String("err", err.Error()))
+
+	requestID := RequestIDFrom(r)
+	if requestID != "" {
+		w.Header().Set(RequestIDHeader, requestID)
+	}
+
+	switch FormatFrom(r) {
+	case FormatProblemJSON:
+		WriteProblem(w, Problem{Title: msg, Status: httpStatus, Detail: err.Error()})
+		return
+	case FormatJSONAPI:
+		WriteJSONAPIErrors(w, httpStatus, []JSONAPIError{{Title: msg, Detail: err.Error()}})
+		return
+	}
+
+	resp := errorResp(msg)
+	resp.RequestID = requestID
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(httpStatus)
-	json.NewEncoder(w).Encode(errorResp(msg))
+	json.NewEncoder(w).Encode(resp)
 }